@@ -0,0 +1,76 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// CreateRequest bundles everything a plugin needs to create a new remote resource, including an
+// IdempotencyToken the framework derives from the effective request (task template custom, resolved
+// cluster/namespace, input pointer, secret version) so repeated Create calls for the same task execution
+// attempt can be recognized as duplicates instead of relying on each plugin reinventing its own scheme.
+type CreateRequest struct {
+	PluginContext
+
+	// IdempotencyToken is a stable, content-addressable hash of the effective request. It is identical across
+	// repeated evaluations of the same task execution attempt, and different whenever the request actually
+	// changes (e.g. a retry with a new input).
+	IdempotencyToken string
+}
+
+// CreateOptions describes how a plugin wants Create invocations handled by the framework.
+type CreateOptions struct {
+	// SupportsIdempotencyToken indicates the plugin forwards CreateRequest.IdempotencyToken to the remote
+	// service's own dedupe mechanism. When true, the framework's local IdempotencyCache is skipped in favor of
+	// that remote-side guarantee.
+	SupportsIdempotencyToken bool
+}
+
+// idempotencyKey identifies a single Create attempt for a task execution.
+type idempotencyKey struct {
+	taskExecutionID  string
+	idempotencyToken string
+}
+
+// IdempotencyCache short-circuits Create for a (TaskExecutionID, IdempotencyToken) pair that was already
+// observed, returning the ResourceKey a prior invocation produced instead of calling the plugin again. It
+// exists for plugins whose remote API has no native dedupe token to forward to.
+type IdempotencyCache struct {
+	mu      sync.Mutex
+	entries map[idempotencyKey]ResourceKey
+}
+
+// NewIdempotencyCache returns an empty IdempotencyCache.
+func NewIdempotencyCache() *IdempotencyCache {
+	return &IdempotencyCache{entries: make(map[idempotencyKey]ResourceKey)}
+}
+
+// Get returns the ResourceKey previously recorded for taskExecutionID/token, if any.
+func (c *IdempotencyCache) Get(taskExecutionID, idempotencyToken string) (ResourceKey, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := c.entries[idempotencyKey{taskExecutionID: taskExecutionID, idempotencyToken: idempotencyToken}]
+	return key, ok
+}
+
+// Put records that taskExecutionID/token produced resourceKey, so a future Create with the same pair can be
+// short-circuited instead of hitting the remote API again.
+func (c *IdempotencyCache) Put(taskExecutionID, idempotencyToken string, resourceKey ResourceKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[idempotencyKey{taskExecutionID: taskExecutionID, idempotencyToken: idempotencyToken}] = resourceKey
+}
+
+// ComputeIdempotencyToken derives a stable content hash of the effective request, so repeated evaluations of
+// the same task execution attempt (e.g. across a framework restart) produce the same token, while a genuine
+// retry with a different input produces a different one.
+func ComputeIdempotencyToken(taskExecutionID fmt.Stringer, custom []byte, resolvedNamespace, inputPath, secretVersion string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s|", taskExecutionID.String(), resolvedNamespace, inputPath, secretVersion)
+	h.Write(custom)
+	return hex.EncodeToString(h.Sum(nil))
+}
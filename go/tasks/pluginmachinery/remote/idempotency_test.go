@@ -0,0 +1,51 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyCache_GetMiss(t *testing.T) {
+	cache := NewIdempotencyCache()
+
+	_, ok := cache.Get("exec-1", "token-1")
+	assert.False(t, ok)
+}
+
+func TestIdempotencyCache_PutThenGet(t *testing.T) {
+	cache := NewIdempotencyCache()
+	want := ResourceKey{Name: "resource-1"}
+
+	cache.Put("exec-1", "token-1", want)
+
+	got, ok := cache.Get("exec-1", "token-1")
+	assert.True(t, ok)
+	assert.Equal(t, want, got)
+}
+
+func TestIdempotencyCache_DistinguishesTaskExecutionAndToken(t *testing.T) {
+	cache := NewIdempotencyCache()
+	cache.Put("exec-1", "token-1", ResourceKey{Name: "resource-1"})
+
+	_, ok := cache.Get("exec-1", "token-2")
+	assert.False(t, ok, "a different token for the same execution must not hit")
+
+	_, ok = cache.Get("exec-2", "token-1")
+	assert.False(t, ok, "the same token for a different execution must not hit")
+}
+
+func TestComputeIdempotencyToken_StableAndSensitive(t *testing.T) {
+	id := stringerID("exec-1")
+
+	a := ComputeIdempotencyToken(id, []byte("custom"), "ns", "input", "v1")
+	b := ComputeIdempotencyToken(id, []byte("custom"), "ns", "input", "v1")
+	assert.Equal(t, a, b, "the same inputs must produce the same token")
+
+	c := ComputeIdempotencyToken(id, []byte("custom-changed"), "ns", "input", "v1")
+	assert.NotEqual(t, a, c, "a different custom payload must produce a different token")
+}
+
+type stringerID string
+
+func (s stringerID) String() string { return string(s) }
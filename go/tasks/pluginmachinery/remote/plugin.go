@@ -51,6 +51,38 @@ type PluginContext interface {
 
 	// Provides an output sync of type io.OutputWriter
 	OutputWriter() io.OutputWriter
+
+	// PluginStateReader returns state this plugin persisted on a prior evaluation of this task execution.
+	PluginStateReader() PluginStateReader
+
+	// PluginStateWriter persists plugin state so it is available via PluginStateReader on the next evaluation
+	// of this task execution.
+	PluginStateWriter() PluginStateWriter
+
+	// RoutingDecision returns the RoutingDecision the framework resolved for this task evaluation from the
+	// plugin's declared PluginProperties.Routing, before ResourceRequirements/Create were invoked.
+	RoutingDecision() RoutingDecision
+}
+
+// PluginStateReader retrieves a plugin-defined struct that was persisted via PluginStateWriter on a previous
+// evaluation of this task execution, together with the version tag it was stored under.
+type PluginStateReader interface {
+	// GetStateVersion returns the version tag the currently persisted state was stored with.
+	GetStateVersion() uint8
+
+	// Get unmarshals the persisted state into t and returns the version it was stored under. t is untouched,
+	// and the returned version is 0, if nothing has been persisted yet.
+	Get(t interface{}) (stateVersion uint8, err error)
+}
+
+// PluginStateWriter persists a plugin-defined struct under a version tag so it is available via
+// PluginStateReader on the next evaluation of this task execution.
+type PluginStateWriter interface {
+	// Put marshals and persists t under stateVersion.
+	Put(stateVersion uint8, t interface{}) error
+
+	// Reset discards any previously persisted state for this task execution.
+	Reset() error
 }
 
 // Name/Identifier of the resource in the remote service.
@@ -62,18 +94,53 @@ type ResourceKey struct {
 // The resource to be sycned from the remote
 type Resource interface{}
 
+// PluginProperties encapsulates static settings a plugin advertises to the framework, which in turn adjusts
+// how it orchestrates calls into the plugin.
+type PluginProperties struct {
+	// SupportsWatch indicates this plugin also implements Watcher and wants the framework to drive its phase
+	// transitions from that event stream instead of polling Get/Status on every task evaluation.
+	SupportsWatch bool
+
+	// Routing declares how the framework should resolve this plugin's destination (cluster, workgroup,
+	// catalog, ...) before ResourceRequirements/Create are invoked. Zero-value RoutingSpec means the plugin
+	// resolves its own routing.
+	Routing RoutingSpec
+}
+
+// Watcher is implemented by plugins whose remote service can push updates (webhooks, SNS/SQS, Pub/Sub, ...)
+// instead of being polled. A plugin that sets PluginProperties.SupportsWatch should implement this alongside
+// Plugin so the framework can subscribe once per resource via a WatchMultiplexer and translate each delivered
+// Resource into a PhaseInfo as soon as it arrives, rather than waiting on the next cache.AutoRefresh pass.
+type Watcher interface {
+	// Watch subscribes to updates for the resource identified by key. The returned channel receives a Resource
+	// every time the remote system reports a change, and is closed once the subscription ends, whether because
+	// ctx was cancelled or because the remote side closed it.
+	Watch(ctx context.Context, key ResourceKey) (<-chan Resource, error)
+}
+
 // Defines a simplified interface to author plugins for k8s resources.
 type Plugin interface {
 	GetPluginProperties() PluginProperties
 
+	// StateVersion identifies the schema of the struct this plugin persists through PluginStateWriter. The
+	// framework discards previously persisted state (handing back a zero-value PluginStateReader instead of
+	// erroring) whenever the version it finds no longer matches this value, so plugins can evolve their state
+	// struct across releases without having to write a migration.
+	StateVersion() uint8
+
 	// Analyzes the task to execute and determines the ResourceNamespace to be used when allocating
 	// tokens.
 	ResourceRequirements(ctx context.Context, tCtx PluginContext) (
 		namespace pluginsCore.ResourceNamespace, constraints pluginsCore.ResourceConstraintsSpec, err error)
 
-	// Create a new resource using the PluginContext provided. Ideally, the remote service uses the name in the
-	// TaskExecutionMetadata to launch the resource in an idempotent fashion.
-	Create(ctx context.Context, tCtx PluginContext) (createdResources ResourceKey, err error)
+	// CreateOptions describes how the framework should handle Create calls for this plugin.
+	CreateOptions() CreateOptions
+
+	// Create a new resource using the CreateRequest provided. Ideally, the remote service uses the name in the
+	// TaskExecutionMetadata to launch the resource in an idempotent fashion. Plugins that advertise
+	// CreateOptions().SupportsIdempotencyToken should additionally forward request.IdempotencyToken to the
+	// remote API's own dedupe mechanism (e.g. Athena's ClientRequestToken, BigQuery's jobReference.jobId).
+	Create(ctx context.Context, request CreateRequest) (createdResources ResourceKey, err error)
 
 	// Get multiple resources that match all the keys. If the plugin hits any failure, it should stop and return
 	// the failure. This batch will not be processed further.
@@ -82,6 +149,8 @@ type Plugin interface {
 	// Delete the object in the remote API using the resource key
 	Delete(ctx context.Context, key ResourceKey) error
 
-	// Status checks the status of a given resource and translates it to a Flyte-understandable PhaseInfo.
-	Status(ctx context.Context, resource Resource) (phase pluginsCore.PhaseInfo, err error)
+	// Status checks the status of a given resource, translates it to a Flyte-understandable PhaseInfo, and
+	// returns any ResourceResults observed along the way (e.g. bytes scanned, chosen cluster, cost estimate),
+	// for downstream lineage and metrics.
+	Status(ctx context.Context, resource Resource) (phase pluginsCore.PhaseInfo, results []ResourceResult, err error)
 }
\ No newline at end of file
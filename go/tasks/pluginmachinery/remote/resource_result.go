@@ -0,0 +1,139 @@
+package remote
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	pluginsCore "github.com/lyft/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/lyft/flytestdlib/promutils"
+)
+
+// ResourceResultType enumerates the shape of a ResourceResult.Value, so downstream consumers can interpret it
+// without guessing from the string content.
+type ResourceResultType int
+
+const (
+	ResourceResultTypeString ResourceResultType = iota
+	ResourceResultTypeInt
+	ResourceResultTypeDuration
+	ResourceResultTypeBytes
+	ResourceResultTypeURI
+)
+
+// ResourceResult captures a single piece of structured information a plugin observed about a remote resource
+// -- e.g. bytes scanned, rows produced, the cluster a query ran on, a cost estimate, or a link to the remote
+// system's own UI -- so it can be surfaced through Flyte's event stream for lineage and analytics, instead of
+// being squeezed into a single hand-rolled log name.
+type ResourceResult struct {
+	Name      string
+	Type      ResourceResultType
+	Value     string
+	EmittedAt time.Time
+}
+
+// MergeResourceResults folds results into info.Custom, overwriting any previously merged results under the
+// same names. It is a no-op if info is nil or results is empty.
+func MergeResourceResults(info *pluginsCore.TaskInfo, results []ResourceResult) {
+	if info == nil || len(results) == 0 {
+		return
+	}
+
+	fields := make(map[string]*structpb.Value, len(results))
+	if info.Custom != nil {
+		for k, v := range info.Custom.Fields {
+			fields[k] = v
+		}
+	}
+	for _, r := range results {
+		fields[r.Name] = resourceResultValue(r)
+		fields[r.Name+".emitted_at"] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: r.EmittedAt.Format(time.RFC3339)}}
+	}
+
+	info.Custom = &structpb.Struct{Fields: fields}
+}
+
+// resourceResultValue encodes r.Value as a structpb.Value matching r.Type, so downstream consumers can
+// interpret it without guessing from the string content, per ResourceResultType's doc comment.
+func resourceResultValue(r ResourceResult) *structpb.Value {
+	switch r.Type {
+	case ResourceResultTypeInt, ResourceResultTypeDuration, ResourceResultTypeBytes:
+		if value, err := strconv.ParseFloat(r.Value, 64); err == nil {
+			return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: value}}
+		}
+	}
+	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: r.Value}}
+}
+
+// ResultMetrics lazily creates and caches one Prometheus metric per ResourceResult.Name, auto-registered under
+// a plugin's MetricsScope, so numeric ResourceResults show up as Prometheus summaries (no histograms are
+// registered) without each plugin author having
+// to hand-declare a metric for every field they decide to emit. ResourceResultTypeDuration values are recorded
+// as durations; ResourceResultTypeInt/ResourceResultTypeBytes values are plain counts and recorded as summaries
+// instead, so a byte count is never misreported as a number of seconds.
+type ResultMetrics struct {
+	scope promutils.Scope
+
+	mu          sync.Mutex
+	stopWatches map[string]promutils.StopWatch
+	summaries   map[string]promutils.Summary
+}
+
+// NewResultMetrics returns a ResultMetrics that registers its metrics under scope.
+func NewResultMetrics(scope promutils.Scope) *ResultMetrics {
+	return &ResultMetrics{
+		scope:       scope,
+		stopWatches: make(map[string]promutils.StopWatch),
+		summaries:   make(map[string]promutils.Summary),
+	}
+}
+
+// Observe records result under its own auto-created metric, if its Value parses as numeric. Results whose
+// Type is not numeric (ResourceResultTypeString, ResourceResultTypeURI) are skipped.
+func (m *ResultMetrics) Observe(result ResourceResult) {
+	value, err := strconv.ParseFloat(result.Value, 64)
+	if err != nil {
+		return
+	}
+
+	switch result.Type {
+	case ResourceResultTypeDuration:
+		m.stopWatch(result.Name).Observe(time.Duration(value * float64(time.Second)))
+	case ResourceResultTypeInt, ResourceResultTypeBytes:
+		m.summary(result.Name).Observe(value)
+	}
+}
+
+func (m *ResultMetrics) stopWatch(name string) promutils.StopWatch {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stopWatch, ok := m.stopWatches[name]
+	if !ok {
+		stopWatch = m.scope.MustNewStopWatch(sanitizeMetricName(name),
+			fmt.Sprintf("Observed durations for resource result %q", name), time.Second)
+		m.stopWatches[name] = stopWatch
+	}
+	return stopWatch
+}
+
+func (m *ResultMetrics) summary(name string) promutils.Summary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary, ok := m.summaries[name]
+	if !ok {
+		summary = m.scope.MustNewSummary(sanitizeMetricName(name),
+			fmt.Sprintf("Observed values for resource result %q", name))
+		m.summaries[name] = summary
+	}
+	return summary
+}
+
+func sanitizeMetricName(name string) string {
+	return strings.ReplaceAll(name, ".", "_")
+}
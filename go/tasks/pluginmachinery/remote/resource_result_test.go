@@ -0,0 +1,65 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+
+	pluginsCore "github.com/lyft/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/lyft/flytestdlib/promutils"
+)
+
+func TestMergeResourceResults_EncodesByType(t *testing.T) {
+	emittedAt := time.Unix(1700000000, 0).UTC()
+	info := &pluginsCore.TaskInfo{}
+
+	MergeResourceResults(info, []ResourceResult{
+		{Name: "qubole.command_id", Type: ResourceResultTypeString, Value: "cmd-1", EmittedAt: emittedAt},
+		{Name: "qubole.wait_time_seconds", Type: ResourceResultTypeDuration, Value: "12.5", EmittedAt: emittedAt},
+		{Name: "bytes_scanned", Type: ResourceResultTypeBytes, Value: "500000", EmittedAt: emittedAt},
+	})
+
+	assert.Equal(t, &structpb.Value_StringValue{StringValue: "cmd-1"}, info.Custom.Fields["qubole.command_id"].Kind)
+	assert.Equal(t, &structpb.Value_NumberValue{NumberValue: 12.5}, info.Custom.Fields["qubole.wait_time_seconds"].Kind)
+	assert.Equal(t, &structpb.Value_NumberValue{NumberValue: 500000}, info.Custom.Fields["bytes_scanned"].Kind)
+	assert.Equal(t, emittedAt.Format(time.RFC3339), info.Custom.Fields["qubole.command_id.emitted_at"].GetStringValue())
+}
+
+func TestMergeResourceResults_PreservesExistingCustomFields(t *testing.T) {
+	info := &pluginsCore.TaskInfo{
+		Custom: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"preexisting": {Kind: &structpb.Value_StringValue{StringValue: "keep-me"}},
+		}},
+	}
+
+	MergeResourceResults(info, []ResourceResult{
+		{Name: "qubole.command_id", Type: ResourceResultTypeString, Value: "cmd-1"},
+	})
+
+	assert.Equal(t, "keep-me", info.Custom.Fields["preexisting"].GetStringValue())
+	assert.Equal(t, "cmd-1", info.Custom.Fields["qubole.command_id"].GetStringValue())
+}
+
+func TestMergeResourceResults_NoopOnEmpty(t *testing.T) {
+	MergeResourceResults(nil, []ResourceResult{{Name: "x"}})
+
+	info := &pluginsCore.TaskInfo{}
+	MergeResourceResults(info, nil)
+	assert.Nil(t, info.Custom)
+}
+
+func TestResultMetrics_ObserveDoesNotPanicAcrossTypes(t *testing.T) {
+	metrics := NewResultMetrics(promutils.NewTestScope())
+
+	// A bytes count of 500000 must never be routed through the duration StopWatch (it would previously be
+	// recorded as ~5.8 days); Int/Bytes go through the summary path instead, Duration through the stopwatch.
+	metrics.Observe(ResourceResult{Name: "bytes_scanned", Type: ResourceResultTypeBytes, Value: "500000"})
+	metrics.Observe(ResourceResult{Name: "rows_produced", Type: ResourceResultTypeInt, Value: "42"})
+	metrics.Observe(ResourceResult{Name: "qubole.wait_time_seconds", Type: ResourceResultTypeDuration, Value: "12.5"})
+
+	// Non-numeric types and unparseable values are silently skipped.
+	metrics.Observe(ResourceResult{Name: "qubole.query_uri", Type: ResourceResultTypeURI, Value: "https://example.com"})
+	metrics.Observe(ResourceResult{Name: "bad_value", Type: ResourceResultTypeInt, Value: "not-a-number"})
+}
@@ -0,0 +1,127 @@
+package remote
+
+import (
+	"context"
+
+	"github.com/lyft/flytestdlib/promutils"
+)
+
+// RoutingRule maps a (project, domain) pair to a destination label, mirroring the project/domain override
+// tables remote-service plugins (Qubole cluster labels, Athena workgroups, BigQuery projects, ...) already
+// maintain by hand today.
+type RoutingRule struct {
+	Project string
+	Domain  string
+	Label   string
+}
+
+// RoutingSpec declares how a plugin wants the framework to resolve its destination route, following the
+// override -> project/domain lookup -> default pattern every remote-service plugin ends up reinventing.
+type RoutingSpec struct {
+	// OverrideExtractor pulls an explicit, user-supplied route out of the task, e.g. a ClusterLabel field on a
+	// plugin's custom proto. Returning "" means no override was given. May be nil if a plugin has no concept
+	// of a per-task override.
+	OverrideExtractor func(ctx context.Context, tCtx PluginContext) (string, error)
+
+	// ProjectDomainTable maps (project, domain) to a destination label, consulted when no override is present.
+	ProjectDomainTable []RoutingRule
+
+	// Default is returned when neither an override nor a project/domain match is found.
+	Default string
+
+	// Aliases maps a primary label to the set of labels that resolve to it.
+	Aliases map[string][]string
+}
+
+// RoutingDecision is the resolved output of a RoutingSpec for a single task evaluation.
+type RoutingDecision struct {
+	// PrimaryLabel is the canonical destination label after alias resolution.
+	PrimaryLabel string
+
+	// UsedOverride indicates the decision came from RoutingSpec.OverrideExtractor.
+	UsedOverride bool
+
+	// UsedDefault indicates the default label was used, either because no override or project/domain match was
+	// found, or because a match was found but its label did not resolve to a known alias.
+	UsedDefault bool
+
+	// UnknownLabel indicates a project/domain match was found, but its label did not resolve to a known alias,
+	// so Default was used instead. An override that does not resolve is not flagged; resolution just falls
+	// through to the project/domain lookup.
+	UnknownLabel bool
+}
+
+// resolveAlias walks aliases to find the primary label that the given label resolves to.
+func resolveAlias(aliases map[string][]string, label string) (string, bool) {
+	if label == "" {
+		return "", false
+	}
+
+	for primary, members := range aliases {
+		for _, m := range members {
+			if m == label {
+				return primary, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Resolve applies the override -> project/domain -> default resolution order described by spec. Callers
+// extract override, project and domain themselves, since those are read from different concrete types across
+// the remote and legacy plugin contracts.
+func (spec RoutingSpec) Resolve(override, project, domain string) RoutingDecision {
+	if override != "" {
+		if primary, found := resolveAlias(spec.Aliases, override); found {
+			return RoutingDecision{PrimaryLabel: primary, UsedOverride: true}
+		}
+	}
+
+	for _, rule := range spec.ProjectDomainTable {
+		if rule.Project == project && rule.Domain == domain {
+			if primary, found := resolveAlias(spec.Aliases, rule.Label); found {
+				return RoutingDecision{PrimaryLabel: primary}
+			}
+			// The table points at a label with no known alias (e.g. a typo'd or stale config entry); fall
+			// back to the safe default rather than routing to an unresolved, arbitrary string.
+			return RoutingDecision{PrimaryLabel: spec.Default, UsedDefault: true, UnknownLabel: true}
+		}
+	}
+
+	return RoutingDecision{PrimaryLabel: spec.Default, UsedDefault: true}
+}
+
+// RoutingMetrics counts how a RoutingSpec resolved routes across evaluations, so operators can see how often
+// overrides, fallbacks to the default, and unresolvable labels are hit. Fallback also counts the fallbacks
+// caused by an unresolvable label; resolutions through a known project/domain mapping are not counted.
+type RoutingMetrics struct {
+	Override promutils.Counter
+	Fallback promutils.Counter
+	Unknown  promutils.Counter
+}
+
+// NewRoutingMetrics registers a RoutingMetrics under scope.
+func NewRoutingMetrics(scope promutils.Scope) RoutingMetrics {
+	return RoutingMetrics{
+		Override: scope.MustNewCounter("routing_override", "Count of routing decisions resolved via an explicit override"),
+		Fallback: scope.MustNewCounter("routing_fallback", "Count of routing decisions that fell back to the default label"),
+		Unknown:  scope.MustNewCounter("routing_unknown_label", "Count of routing decisions where the matched label had no known alias"),
+	}
+}
+
+// ResolveWithMetrics resolves spec exactly like Resolve, additionally incrementing metrics for override,
+// fallback-to-default, and unknown-label usage.
+func (spec RoutingSpec) ResolveWithMetrics(override, project, domain string, metrics RoutingMetrics) RoutingDecision {
+	decision := spec.Resolve(override, project, domain)
+	if decision.UsedOverride {
+		metrics.Override.Inc()
+	}
+	if decision.UsedDefault {
+		metrics.Fallback.Inc()
+	}
+	if decision.UnknownLabel {
+		metrics.Unknown.Inc()
+	}
+	return decision
+}
@@ -0,0 +1,91 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/lyft/flytestdlib/promutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutingSpec_Resolve(t *testing.T) {
+	spec := RoutingSpec{
+		ProjectDomainTable: []RoutingRule{
+			{Project: "flyteexamples", Domain: "production", Label: "big-cluster"},
+			{Project: "flyteexamples", Domain: "staging", Label: "unmapped-label"},
+		},
+		Default: "default-cluster",
+		Aliases: map[string][]string{
+			"big-cluster": {"big-cluster", "big-cluster-legacy"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		project  string
+		domain   string
+		want     RoutingDecision
+	}{
+		{
+			name:     "override resolves via alias",
+			override: "big-cluster-legacy",
+			want:     RoutingDecision{PrimaryLabel: "big-cluster", UsedOverride: true},
+		},
+		{
+			name:    "project/domain match resolves via alias",
+			project: "flyteexamples",
+			domain:  "production",
+			want:    RoutingDecision{PrimaryLabel: "big-cluster"},
+		},
+		{
+			name:    "project/domain match with unknown label falls back to default",
+			project: "flyteexamples",
+			domain:  "staging",
+			want:    RoutingDecision{PrimaryLabel: "default-cluster", UsedDefault: true, UnknownLabel: true},
+		},
+		{
+			name:    "no match falls back to default",
+			project: "other",
+			domain:  "production",
+			want:    RoutingDecision{PrimaryLabel: "default-cluster", UsedDefault: true},
+		},
+		{
+			name:     "override with no known alias falls through to project/domain lookup",
+			override: "nonexistent-label",
+			project:  "flyteexamples",
+			domain:   "production",
+			want:     RoutingDecision{PrimaryLabel: "big-cluster"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spec.Resolve(tt.override, tt.project, tt.domain)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRoutingSpec_ResolveWithMetrics(t *testing.T) {
+	spec := RoutingSpec{
+		ProjectDomainTable: []RoutingRule{
+			{Project: "flyteexamples", Domain: "staging", Label: "unmapped-label"},
+		},
+		Default: "default-cluster",
+		Aliases: map[string][]string{
+			"big-cluster": {"big-cluster"},
+		},
+	}
+	metrics := NewRoutingMetrics(promutils.NewTestScope())
+
+	// ResolveWithMetrics must return the exact same decisions as Resolve, for each branch, while additionally
+	// incrementing the matching counter -- exercised here mainly to confirm it doesn't panic when wired up.
+	got := spec.ResolveWithMetrics("big-cluster", "", "", metrics)
+	assert.Equal(t, RoutingDecision{PrimaryLabel: "big-cluster", UsedOverride: true}, got)
+
+	got = spec.ResolveWithMetrics("", "flyteexamples", "staging", metrics)
+	assert.Equal(t, RoutingDecision{PrimaryLabel: "default-cluster", UsedDefault: true, UnknownLabel: true}, got)
+
+	got = spec.ResolveWithMetrics("", "other", "other", metrics)
+	assert.Equal(t, RoutingDecision{PrimaryLabel: "default-cluster", UsedDefault: true}, got)
+}
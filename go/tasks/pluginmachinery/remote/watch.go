@@ -0,0 +1,161 @@
+package remote
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pluginsCore "github.com/lyft/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/lyft/flytestdlib/logger"
+)
+
+// watchEntry holds the latest event delivered for a single watched resource, plus the means to tear the
+// subscription down once it is no longer needed.
+type watchEntry struct {
+	mu       sync.Mutex
+	latest   pluginsCore.PhaseInfo
+	hasEvent bool
+	cancel   context.CancelFunc
+}
+
+// WatchMultiplexer subscribes to a Watcher-capable plugin at most once per resource and buffers the most
+// recently delivered phase, so the next task evaluation can return immediately with the newest phase instead
+// of waiting on the plugin's auto-refresh cache to get around to polling again.
+type WatchMultiplexer struct {
+	plugin  Plugin
+	watcher Watcher
+
+	mu      sync.Mutex
+	entries map[ResourceKey]*watchEntry
+}
+
+// NewWatchMultiplexer constructs a multiplexer for a plugin that implements Watcher. Callers should keep a
+// single instance per plugin.
+func NewWatchMultiplexer(plugin Plugin, watcher Watcher) *WatchMultiplexer {
+	return &WatchMultiplexer{
+		plugin:  plugin,
+		watcher: watcher,
+		entries: make(map[ResourceKey]*watchEntry),
+	}
+}
+
+// Subscribe starts a subscription for key if one isn't already running. It is safe to call on every task
+// evaluation; once a subscription exists, subsequent calls are no-ops.
+func (w *WatchMultiplexer) Subscribe(ctx context.Context, key ResourceKey) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.entries[key]; ok {
+		return nil
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	events, err := w.watcher.Watch(watchCtx, key)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	entry := &watchEntry{cancel: cancel}
+	w.entries[key] = entry
+	go w.consume(watchCtx, key, entry, events)
+	return nil
+}
+
+func (w *WatchMultiplexer) consume(ctx context.Context, key ResourceKey, entry *watchEntry, events <-chan Resource) {
+	for {
+		select {
+		case resource, ok := <-events:
+			if !ok {
+				return
+			}
+
+			phaseInfo, results, err := w.plugin.Status(ctx, resource)
+			if err != nil {
+				logger.Errorf(ctx, "WatchMultiplexer: failed to translate watched resource for key [%v]: %s", key, err)
+				continue
+			}
+			if len(results) > 0 {
+				MergeResourceResults(phaseInfo.Info(), results)
+			}
+
+			entry.mu.Lock()
+			entry.latest = phaseInfo
+			entry.hasEvent = true
+			entry.mu.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Latest returns the most recently buffered PhaseInfo for key, and whether an event has been delivered yet.
+func (w *WatchMultiplexer) Latest(key ResourceKey) (pluginsCore.PhaseInfo, bool) {
+	w.mu.Lock()
+	entry, ok := w.entries[key]
+	w.mu.Unlock()
+	if !ok {
+		return pluginsCore.PhaseInfo{}, false
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	return entry.latest, entry.hasEvent
+}
+
+// Unsubscribe tears down the subscription for key, e.g. once the resource reaches a terminal phase.
+func (w *WatchMultiplexer) Unsubscribe(key ResourceKey) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if entry, ok := w.entries[key]; ok {
+		entry.cancel()
+		delete(w.entries, key)
+	}
+}
+
+// PollingWatcher adapts a plain Plugin that does not implement Watcher into the Watcher interface by polling
+// Get on a fixed interval. This lets the framework apply the same event-driven subscription path uniformly,
+// even for remote services with no native push/webhook support.
+type PollingWatcher struct {
+	plugin   Plugin
+	interval time.Duration
+}
+
+// NewPollingWatcher returns a fallback Watcher that emulates watch semantics for plugins without native
+// support, by polling plugin.Get every interval.
+func NewPollingWatcher(plugin Plugin, interval time.Duration) *PollingWatcher {
+	return &PollingWatcher{plugin: plugin, interval: interval}
+}
+
+func (p *PollingWatcher) Watch(ctx context.Context, key ResourceKey) (<-chan Resource, error) {
+	ch := make(chan Resource)
+
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				resource, err := p.plugin.Get(ctx, key)
+				if err != nil {
+					logger.Errorf(ctx, "PollingWatcher: Get failed for key [%v]: %s", key, err)
+					continue
+				}
+
+				select {
+				case ch <- resource:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,131 @@
+package remote
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	pluginsCore "github.com/lyft/flyteplugins/go/tasks/pluginmachinery/core"
+)
+
+// fakeWatcherPlugin is a minimal Plugin+Watcher double. Only Status and Get are exercised by the tests in this
+// file; the remaining Plugin methods exist solely to satisfy the interface.
+type fakeWatcherPlugin struct {
+	mu        sync.Mutex
+	events    chan Resource
+	getCalls  int
+	getResult Resource
+}
+
+func (f *fakeWatcherPlugin) GetPluginProperties() PluginProperties { return PluginProperties{} }
+func (f *fakeWatcherPlugin) StateVersion() uint8                   { return 0 }
+func (f *fakeWatcherPlugin) ResourceRequirements(ctx context.Context, tCtx PluginContext) (
+	pluginsCore.ResourceNamespace, pluginsCore.ResourceConstraintsSpec, error) {
+	return "", pluginsCore.ResourceConstraintsSpec{}, nil
+}
+func (f *fakeWatcherPlugin) CreateOptions() CreateOptions { return CreateOptions{} }
+func (f *fakeWatcherPlugin) Create(ctx context.Context, request CreateRequest) (ResourceKey, error) {
+	return ResourceKey{}, nil
+}
+func (f *fakeWatcherPlugin) Get(ctx context.Context, key ResourceKey) (Resource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getCalls++
+	return f.getResult, nil
+}
+func (f *fakeWatcherPlugin) Delete(ctx context.Context, key ResourceKey) error { return nil }
+func (f *fakeWatcherPlugin) Status(ctx context.Context, resource Resource) (pluginsCore.PhaseInfo, []ResourceResult, error) {
+	return pluginsCore.PhaseInfoRunning(0, nil), nil, nil
+}
+
+func (f *fakeWatcherPlugin) Watch(ctx context.Context, key ResourceKey) (<-chan Resource, error) {
+	return f.events, nil
+}
+
+func (f *fakeWatcherPlugin) getCallCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.getCalls
+}
+
+func TestWatchMultiplexer_SubscribeDeliversLatest(t *testing.T) {
+	plugin := &fakeWatcherPlugin{events: make(chan Resource, 1)}
+	mux := NewWatchMultiplexer(plugin, plugin)
+	key := ResourceKey{Name: "res-1"}
+
+	_, hasEvent := mux.Latest(key)
+	assert.False(t, hasEvent, "no event should be buffered before Subscribe")
+
+	assert.NoError(t, mux.Subscribe(context.Background(), key))
+	plugin.events <- struct{}{}
+
+	assert.Eventually(t, func() bool {
+		_, ok := mux.Latest(key)
+		return ok
+	}, time.Second, time.Millisecond)
+}
+
+func TestWatchMultiplexer_SubscribeIsIdempotent(t *testing.T) {
+	plugin := &fakeWatcherPlugin{events: make(chan Resource, 1)}
+	mux := NewWatchMultiplexer(plugin, plugin)
+	key := ResourceKey{Name: "res-1"}
+
+	assert.NoError(t, mux.Subscribe(context.Background(), key))
+	assert.NoError(t, mux.Subscribe(context.Background(), key))
+
+	mux.mu.Lock()
+	entryCount := len(mux.entries)
+	mux.mu.Unlock()
+	assert.Equal(t, 1, entryCount, "a second Subscribe for the same key must not start a second subscription")
+}
+
+func TestWatchMultiplexer_Unsubscribe(t *testing.T) {
+	plugin := &fakeWatcherPlugin{events: make(chan Resource, 1)}
+	mux := NewWatchMultiplexer(plugin, plugin)
+	key := ResourceKey{Name: "res-1"}
+
+	assert.NoError(t, mux.Subscribe(context.Background(), key))
+	mux.Unsubscribe(key)
+
+	_, hasEvent := mux.Latest(key)
+	assert.False(t, hasEvent, "Latest should report no entry once unsubscribed")
+}
+
+func TestPollingWatcher_PollsOnInterval(t *testing.T) {
+	plugin := &fakeWatcherPlugin{getResult: struct{}{}}
+	watcher := NewPollingWatcher(plugin, 5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := watcher.Watch(ctx, ResourceKey{Name: "res-1"})
+	assert.NoError(t, err)
+
+	select {
+	case <-events:
+	case <-time.After(time.Second):
+		t.Fatal("expected PollingWatcher to deliver an event within the timeout")
+	}
+	assert.GreaterOrEqual(t, plugin.getCallCount(), 1)
+}
+
+func TestPollingWatcher_StopsOnContextCancel(t *testing.T) {
+	plugin := &fakeWatcherPlugin{getResult: struct{}{}}
+	watcher := NewPollingWatcher(plugin, 5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := watcher.Watch(ctx, ResourceKey{Name: "res-1"})
+	assert.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "channel should be closed after context cancellation")
+	case <-time.After(time.Second):
+		t.Fatal("expected the events channel to close after cancellation")
+	}
+}
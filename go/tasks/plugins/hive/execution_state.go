@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/lyft/flytestdlib/cache"
+	"github.com/lyft/flytestdlib/promutils"
 
 	idlCore "github.com/lyft/flyteidl/gen/pb-go/flyteidl/core"
 	"github.com/lyft/flyteidl/gen/pb-go/flyteidl/plugins"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/lyft/flyteplugins/go/tasks/errors"
 	"github.com/lyft/flyteplugins/go/tasks/pluginmachinery/core"
+	"github.com/lyft/flyteplugins/go/tasks/pluginmachinery/remote"
 	"github.com/lyft/flyteplugins/go/tasks/plugins/hive/client"
 	"github.com/lyft/flytestdlib/logger"
 )
@@ -47,6 +49,27 @@ func (p ExecutionPhase) String() string {
 	return "Bad Qubole execution phase"
 }
 
+// executionStateVersion is the schema version ExecutionState is persisted under via PluginState. Bump this
+// whenever a field is added or removed so the framework discards state from an older schema instead of
+// failing to unmarshal it.
+const executionStateVersion uint8 = 1
+
+// routingMetrics counts how composeResourceNamespaceWithClusterPrimaryLabel resolved cluster routing
+// (override/project-domain-default/unknown-label usage), the same way any other remote-service plugin built on
+// remote.RoutingSpec would report it.
+var routingMetrics = remote.NewRoutingMetrics(promutils.NewScope("hive:routing:"))
+
+// resultMetrics observes the numeric remote.ResourceResults constructResourceResults emits (e.g.
+// qubole.wait_time_seconds), so they show up as their own Prometheus summaries without hand-declaring one per
+// field.
+var resultMetrics = remote.NewResultMetrics(promutils.NewScope("hive:results:"))
+
+// idempotencyCache deduplicates Qubole command submissions within a single evaluator's lifetime, so a repeated
+// KickOffQuery for the same task execution attempt (e.g. after the submitted state failed to persist) reuses the
+// already-submitted command instead of launching a second one. It is held in memory only and starts empty after
+// a framework restart.
+var idempotencyCache = remote.NewIdempotencyCache()
+
 type ExecutionState struct {
 	Phase ExecutionPhase
 
@@ -64,6 +87,30 @@ type ExecutionState struct {
 
 	// The time the execution first requests for an allocation token
 	AllocationTokenRequestStartTime time.Time `json:"allocation_token_request_start_time,omitempty"`
+
+	// The cluster primary label chosen by routing resolution for this task execution. Cached here so
+	// GetAllocationToken/KickOffQuery/Finalize don't have to re-parse the task template and re-run routing
+	// resolution on every evaluation.
+	ClusterPrimaryLabel string `json:"cluster_primary_label,omitempty"`
+}
+
+// LoadExecutionState reads back the ExecutionState a prior evaluation of this task execution persisted via
+// PluginState. A zero-value ExecutionState is returned on the very first evaluation.
+func LoadExecutionState(tCtx core.TaskExecutionContext) (ExecutionState, error) {
+	existing := ExecutionState{}
+	if _, err := tCtx.PluginStateReader().Get(&existing); err != nil {
+		return ExecutionState{}, errors.Wrapf(errors.RuntimeFailure, err, "Error reading persisted execution state")
+	}
+	return existing, nil
+}
+
+// StoreExecutionState persists newState via PluginState so LoadExecutionState can recover it on the next
+// evaluation of this task execution, without KickOffQuery/MonitorQuery/Finalize having to re-derive it.
+func StoreExecutionState(tCtx core.TaskExecutionContext, newState ExecutionState) error {
+	if err := tCtx.PluginStateWriter().Put(executionStateVersion, &newState); err != nil {
+		return errors.Wrapf(errors.RuntimeFailure, err, "Error persisting execution state")
+	}
+	return nil
 }
 
 // This is the main state iteration
@@ -73,6 +120,18 @@ func HandleExecutionState(ctx context.Context, tCtx core.TaskExecutionContext, c
 	var transformError error
 	var newState ExecutionState
 
+	// The caller (driven off executionsCache) owns Phase/CommandId/URI, but the routing decision cached under
+	// PluginState is the one source of truth for ClusterPrimaryLabel, so hot-path functions below can stop
+	// re-deriving it on every evaluation.
+	if currentState.ClusterPrimaryLabel == "" {
+		if persisted, err := LoadExecutionState(tCtx); err != nil {
+			logger.Warnf(ctx, "Failed to load persisted execution state for [%s]: %s",
+				tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
+		} else {
+			currentState.ClusterPrimaryLabel = persisted.ClusterPrimaryLabel
+		}
+	}
+
 	switch currentState.Phase {
 	case PhaseNotStarted:
 		newState, transformError = GetAllocationToken(ctx, tCtx, currentState, metrics)
@@ -92,6 +151,13 @@ func HandleExecutionState(ctx context.Context, tCtx core.TaskExecutionContext, c
 		transformError = nil
 	}
 
+	if transformError == nil {
+		if err := StoreExecutionState(tCtx, newState); err != nil {
+			logger.Warnf(ctx, "Failed to persist execution state for [%s]: %s",
+				tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName(), err)
+		}
+	}
+
 	return newState, transformError
 }
 
@@ -135,33 +201,79 @@ func ConstructTaskInfo(e ExecutionState) *core.TaskInfo {
 	t := time.Now()
 	if e.CommandId != "" {
 		logs = append(logs, ConstructTaskLog(e))
-		return &core.TaskInfo{
+		info := &core.TaskInfo{
 			Logs:       logs,
 			OccurredAt: &t,
 		}
+		results := constructResourceResults(e, t)
+		remote.MergeResourceResults(info, results)
+		for _, r := range results {
+			resultMetrics.Observe(r)
+		}
+		return info
 	}
 
 	return nil
 }
 
-func composeResourceNamespaceWithClusterPrimaryLabel(ctx context.Context, tCtx core.TaskExecutionContext) (core.ResourceNamespace, error) {
+// constructResourceResults surfaces the command id, the query uri, the resolved cluster, and the time spent
+// waiting for an allocation token as structured remote.ResourceResults, alongside the single task log that
+// ConstructTaskLog still builds, so they can be consumed by lineage and metrics.
+func constructResourceResults(e ExecutionState, emittedAt time.Time) []remote.ResourceResult {
+	results := []remote.ResourceResult{
+		{Name: "qubole.command_id", Type: remote.ResourceResultTypeString, Value: e.CommandId, EmittedAt: emittedAt},
+		{Name: "qubole.cluster_label", Type: remote.ResourceResultTypeString, Value: e.ClusterPrimaryLabel, EmittedAt: emittedAt},
+		{Name: "qubole.query_uri", Type: remote.ResourceResultTypeURI, Value: e.URI, EmittedAt: emittedAt},
+	}
+
+	if !e.AllocationTokenRequestStartTime.IsZero() {
+		waitTime := emittedAt.Sub(e.AllocationTokenRequestStartTime)
+		results = append(results, remote.ResourceResult{
+			Name:      "qubole.wait_time_seconds",
+			Type:      remote.ResourceResultTypeDuration,
+			Value:     strconv.FormatFloat(waitTime.Seconds(), 'f', -1, 64),
+			EmittedAt: emittedAt,
+		})
+	}
+
+	return results
+}
+
+// composeResourceNamespaceWithClusterPrimaryLabel resolves the cluster to route this task to. If currentState
+// already has a cached decision (see ExecutionState.ClusterPrimaryLabel), it is reused as-is instead of
+// re-parsing the task template and re-running routing resolution; otherwise it resolves and returns the
+// updated state for the caller to persist.
+func composeResourceNamespaceWithClusterPrimaryLabel(ctx context.Context, tCtx core.TaskExecutionContext, currentState ExecutionState) (
+	core.ResourceNamespace, ExecutionState, error) {
+
+	if currentState.ClusterPrimaryLabel != "" {
+		return core.ResourceNamespace(currentState.ClusterPrimaryLabel), currentState, nil
+	}
+
 	_, clusterLabelOverride, _, _, err := GetQueryInfo(ctx, tCtx)
 	if err != nil {
-		return "", err
+		return "", currentState, err
 	}
-	clusterPrimaryLabel := getClusterPrimaryLabel(ctx, tCtx, clusterLabelOverride)
-	return core.ResourceNamespace(clusterPrimaryLabel), nil
+
+	tExecId := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
+	project := tExecId.NodeExecutionId.GetExecutionId().GetProject()
+	domain := tExecId.NodeExecutionId.GetExecutionId().GetDomain()
+
+	decision := quboleRoutingSpec(config.GetQuboleConfig()).ResolveWithMetrics(clusterLabelOverride, project, domain, routingMetrics)
+	currentState.ClusterPrimaryLabel = decision.PrimaryLabel
+	return core.ResourceNamespace(currentState.ClusterPrimaryLabel), currentState, nil
 }
 
 func GetAllocationToken(ctx context.Context, tCtx core.TaskExecutionContext, currentState ExecutionState, metric QuboleHiveExecutorMetrics) (ExecutionState, error) {
-	newState := ExecutionState{}
 	uniqueId := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
 
-	clusterPrimaryLabel, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx)
+	clusterPrimaryLabel, currentState, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx, currentState)
 	if err != nil {
-		return newState, errors.Wrapf(errors.ResourceManagerFailure, err, "Error getting query info when requesting allocation token %s", uniqueId)
+		return currentState, errors.Wrapf(errors.ResourceManagerFailure, err, "Error getting query info when requesting allocation token %s", uniqueId)
 	}
 
+	newState := ExecutionState{ClusterPrimaryLabel: currentState.ClusterPrimaryLabel}
+
 	allocationStatus, err := tCtx.ResourceManager().AllocateResource(ctx, clusterPrimaryLabel, uniqueId)
 	if err != nil {
 		logger.Errorf(ctx, "Resource manager failed for TaskExecId [%s] token [%s]. error %s",
@@ -233,67 +345,44 @@ func GetQueryInfo(ctx context.Context, tCtx core.TaskExecutionContext) (
 	return
 }
 
-func mapLabelToPrimaryLabel(ctx context.Context, quboleCfg *config.Config, label string) (string, bool) {
-	primaryLabel := DefaultClusterPrimaryLabel
-	found := false
-
-	if label == "" {
-		logger.Debugf(ctx, "Input cluster label is an empty string; falling back to using the default primary label [%v]", label, DefaultClusterPrimaryLabel)
-		return primaryLabel, found
+// quboleRoutingSpec translates the live Qubole configmap into a remote.RoutingSpec, so cluster label
+// resolution follows the same override -> project/domain lookup -> default pattern every remote-service
+// plugin ends up reinventing, instead of hive hand-rolling it.
+func quboleRoutingSpec(cfg *config.Config) remote.RoutingSpec {
+	aliases := make(map[string][]string, len(cfg.ClusterConfigs))
+	for _, clusterCfg := range cfg.ClusterConfigs {
+		aliases[clusterCfg.PrimaryLabel] = clusterCfg.Labels
 	}
 
-	// Using a linear search because N is small and because of ClusterConfig's struct definition
-	// which is determined specifically for the readability of the corresponding configmap yaml file
-	for _, clusterCfg := range quboleCfg.ClusterConfigs {
-		for _, l := range clusterCfg.Labels {
-			if label != "" && l == label {
-				logger.Debugf(ctx, "Found the primary label [%v] for label [%v]", clusterCfg.PrimaryLabel, label)
-				primaryLabel, found = clusterCfg.PrimaryLabel, true
-				break
-			}
-		}
+	rules := make([]remote.RoutingRule, 0, len(cfg.DestinationClusterConfigs))
+	for _, m := range cfg.DestinationClusterConfigs {
+		rules = append(rules, remote.RoutingRule{Project: m.Project, Domain: m.Domain, Label: m.ClusterLabel})
 	}
 
-	logger.Debugf(ctx, "Cannot find the primary cluster label for label [%v] in configmap; "+
-		"falling back to using the default primary label [%v]", label, DefaultClusterPrimaryLabel)
-	return primaryLabel, found
-}
-
-func mapProjectDomainToDestinationClusterLabel(ctx context.Context, tCtx core.TaskExecutionContext, quboleCfg *config.Config) (string, bool) {
-	tExecId := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID()
-	project := tExecId.NodeExecutionId.GetExecutionId().GetProject()
-	domain := tExecId.NodeExecutionId.GetExecutionId().GetDomain()
-	logger.Debugf(ctx, "No clusterLabelOverride. Finding the pre-defined cluster label for (project: %v, domain: %v)", project, domain)
-	// Using a linear search because N is small
-	for _, m := range quboleCfg.DestinationClusterConfigs {
-		if project == m.Project && domain == m.Domain {
-			logger.Debugf(ctx, "Found the pre-defined cluster label [%v] for (project: %v, domain: %v)", m.ClusterLabel, project, domain)
-			return m.ClusterLabel, true
-		}
+	return remote.RoutingSpec{
+		ProjectDomainTable: rules,
+		Default:            DefaultClusterPrimaryLabel,
+		Aliases:            aliases,
 	}
-
-	// This function finds the label, not primary label, so in the case where no mapping is found, this function should return an empty string
-	return "", false
 }
 
-func getClusterPrimaryLabel(ctx context.Context, tCtx core.TaskExecutionContext, clusterLabelOverride string) string {
-	cfg := config.GetQuboleConfig()
-
-	// If override is not empty and if it has a mapping, we return the mapped primary label
-	if clusterLabelOverride != "" {
-		if primaryLabel, found := mapLabelToPrimaryLabel(ctx, cfg, clusterLabelOverride); found {
-			return primaryLabel
-		}
+// addToExecutionsCache registers state in the AutoRefresh cache under uniqueId, so MonitorQuery starts picking
+// up updates for it. The first time an execution is put in the cache, we know it won't have succeeded yet, so
+// we don't need to look at the returned item.
+func addToExecutionsCache(ctx context.Context, tCtx core.TaskExecutionContext, cache cache.AutoRefresh, uniqueId string, state ExecutionState) error {
+	executionStateCacheItem := ExecutionStateCacheItem{
+		ExecutionState: state,
+		Id:             uniqueId,
 	}
 
-	// If override is empty or if the override does not have a mapping, we return the primary label mapped using (project, domain)
-	if clusterLabel, found := mapProjectDomainToDestinationClusterLabel(ctx, tCtx, cfg); found {
-		primaryLabel, _ := mapLabelToPrimaryLabel(ctx, cfg, clusterLabel)
-		return primaryLabel
+	if _, err := cache.GetOrCreate(uniqueId, executionStateCacheItem); err != nil {
+		// This means that our cache has fundamentally broken... return a system error
+		logger.Errorf(ctx, "Cache failed to GetOrCreate for execution [%s] cache key [%s], owner [%s]. Error %s",
+			tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID(), uniqueId,
+			tCtx.TaskExecutionMetadata().GetOwnerReference(), err)
+		return err
 	}
-
-	// Else we return the default primary label
-	return DefaultClusterPrimaryLabel
+	return nil
 }
 
 func KickOffQuery(ctx context.Context, tCtx core.TaskExecutionContext, currentState ExecutionState, quboleClient client.QuboleClient,
@@ -305,15 +394,33 @@ func KickOffQuery(ctx context.Context, tCtx core.TaskExecutionContext, currentSt
 		return currentState, errors.Wrapf(errors.RuntimeFailure, err, "Failed to read token from secrets manager")
 	}
 
-	query, clusterLabelOverride, tags, timeoutSec, err := GetQueryInfo(ctx, tCtx)
+	query, _, tags, timeoutSec, err := GetQueryInfo(ctx, tCtx)
+	if err != nil {
+		return currentState, err
+	}
+
+	clusterPrimaryLabel, currentState, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx, currentState)
 	if err != nil {
 		return currentState, err
 	}
 
-	clusterPrimaryLabel := getClusterPrimaryLabel(ctx, tCtx, clusterLabelOverride)
+	// Dedupe KickOffQuery evaluations for the same task execution attempt (e.g. Qubole accepted the command but
+	// the state carrying CommandId was not persisted) against submitting a second, duplicate command for an
+	// unchanged request. The in-memory idempotencyCache does not cover a framework restart.
+	idempotencyToken := remote.ComputeIdempotencyToken(tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID(),
+		[]byte(query), string(clusterPrimaryLabel), "", "")
+	if cachedKey, ok := idempotencyCache.Get(uniqueId, idempotencyToken); ok {
+		logger.Infof(ctx, "Reusing previously submitted Qubole command [%s] for %s instead of resubmitting", cachedKey.Name, uniqueId)
+		currentState.CommandId = cachedKey.Name
+		currentState.Phase = PhaseSubmitted
+		if err := addToExecutionsCache(ctx, tCtx, cache, uniqueId, currentState); err != nil {
+			return currentState, err
+		}
+		return currentState, nil
+	}
 
 	cmdDetails, err := quboleClient.ExecuteHiveCommand(ctx, query, timeoutSec,
-		clusterPrimaryLabel, apiKey, tags)
+		string(clusterPrimaryLabel), apiKey, tags)
 	if err != nil {
 		// If we failed, we'll keep the NotStarted state
 		currentState.CreationFailureCount = currentState.CreationFailureCount + 1
@@ -326,19 +433,9 @@ func KickOffQuery(ctx context.Context, tCtx core.TaskExecutionContext, currentSt
 		currentState.CommandId = commandId
 		currentState.Phase = PhaseSubmitted
 		currentState.URI = cmdDetails.URI.String()
+		idempotencyCache.Put(uniqueId, idempotencyToken, remote.ResourceKey{Name: commandId})
 
-		executionStateCacheItem := ExecutionStateCacheItem{
-			ExecutionState: currentState,
-			Id:             uniqueId,
-		}
-
-		// The first time we put it in the cache, we know it won't have succeeded so we don't need to look at it
-		_, err := cache.GetOrCreate(uniqueId, executionStateCacheItem)
-		if err != nil {
-			// This means that our cache has fundamentally broken... return a system error
-			logger.Errorf(ctx, "Cache failed to GetOrCreate for execution [%s] cache key [%s], owner [%s]. Error %s",
-				tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetID(), uniqueId,
-				tCtx.TaskExecutionMetadata().GetOwnerReference(), err)
+		if err := addToExecutionsCache(ctx, tCtx, cache, uniqueId, currentState); err != nil {
 			return currentState, err
 		}
 	}
@@ -388,10 +485,10 @@ func Abort(ctx context.Context, tCtx core.TaskExecutionContext, currentState Exe
 	return nil
 }
 
-func Finalize(ctx context.Context, tCtx core.TaskExecutionContext, _ ExecutionState) error {
+func Finalize(ctx context.Context, tCtx core.TaskExecutionContext, currentState ExecutionState) error {
 	// Release allocation token
 	uniqueId := tCtx.TaskExecutionMetadata().GetTaskExecutionID().GetGeneratedName()
-	clusterPrimaryLabel, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx)
+	clusterPrimaryLabel, _, err := composeResourceNamespaceWithClusterPrimaryLabel(ctx, tCtx, currentState)
 	if err != nil {
 		return errors.Wrapf(errors.ResourceManagerFailure, err, "Error getting query info when releasing allocation token %s", uniqueId)
 	}
@@ -0,0 +1,62 @@
+package hive
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lyft/flyteplugins/go/tasks/pluginmachinery/core/mocks"
+)
+
+// A zero stateVersion/nil error from PluginStateReader.Get covers both the very first evaluation of a task
+// execution (nothing persisted yet) and a version mismatch (the framework discards state from a schema that no
+// longer matches executionStateVersion and hands back the same zero-value result) -- LoadExecutionState treats
+// both identically and just returns the zero-value ExecutionState.
+func TestLoadExecutionState_ZeroValueOnFirstEvaluationOrVersionMismatch(t *testing.T) {
+	reader := &mocks.PluginStateReader{}
+	reader.On("Get", mock.Anything).Return(uint8(0), nil)
+
+	tCtx := &mocks.TaskExecutionContext{}
+	tCtx.On("PluginStateReader").Return(reader)
+
+	state, err := LoadExecutionState(tCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, ExecutionState{}, state)
+}
+
+func TestLoadExecutionState_PropagatesReaderError(t *testing.T) {
+	reader := &mocks.PluginStateReader{}
+	reader.On("Get", mock.Anything).Return(uint8(0), fmt.Errorf("boom"))
+
+	tCtx := &mocks.TaskExecutionContext{}
+	tCtx.On("PluginStateReader").Return(reader)
+
+	_, err := LoadExecutionState(tCtx)
+	assert.Error(t, err)
+}
+
+func TestHandleExecutionState_ReusesCachedClusterPrimaryLabel(t *testing.T) {
+	writer := &mocks.PluginStateWriter{}
+	writer.On("Put", executionStateVersion, mock.Anything).Return(nil)
+
+	// The reader is wired up but must never be consulted: currentState already carries a ClusterPrimaryLabel,
+	// so HandleExecutionState should trust it instead of reloading (and potentially overwriting it with
+	// whatever -- including discarded/zero-value -- state PluginStateReader.Get would otherwise return).
+	reader := &mocks.PluginStateReader{}
+	reader.On("Get", mock.Anything).Return(uint8(0), nil)
+
+	tCtx := &mocks.TaskExecutionContext{}
+	tCtx.On("PluginStateWriter").Return(writer)
+	tCtx.On("PluginStateReader").Return(reader)
+
+	currentState := ExecutionState{Phase: PhaseQuerySucceeded, ClusterPrimaryLabel: "big-cluster"}
+
+	newState, err := HandleExecutionState(context.Background(), tCtx, currentState, nil, nil, nil, QuboleHiveExecutorMetrics{})
+	assert.NoError(t, err)
+	assert.Equal(t, "big-cluster", newState.ClusterPrimaryLabel)
+
+	reader.AssertNotCalled(t, "Get", mock.Anything)
+}